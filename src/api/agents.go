@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/DSamuelHodge/agent360/src/middleware"
+	"github.com/DSamuelHodge/agent360/src/store"
+)
+
+// AgentsAPI holds the dependencies for the /api/v1/agents routes.
+type AgentsAPI struct {
+	Store       store.Store
+	Log         *zap.Logger
+	MaxPageSize int
+}
+
+// page is the envelope returned by the list endpoints.
+type page struct {
+	Items      interface{} `json:"items"`
+	NextCursor string      `json:"next_cursor"`
+}
+
+func (a *AgentsAPI) logger(r *http.Request) *zap.Logger {
+	return middleware.LoggerFromContext(r.Context(), a.Log)
+}
+
+// listOptsFromRequest parses ?limit= and ?cursor=, clamping limit to
+// [1, MaxPageSize].
+func (a *AgentsAPI) listOptsFromRequest(r *http.Request) store.ListOpts {
+	limit := store.DefaultPageSize
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+	if a.MaxPageSize > 0 && limit > a.MaxPageSize {
+		limit = a.MaxPageSize
+	}
+	return store.ListOpts{
+		Limit:  limit,
+		Cursor: r.URL.Query().Get("cursor"),
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+func (a *AgentsAPI) ListAgents(w http.ResponseWriter, r *http.Request) {
+	opts := a.listOptsFromRequest(r)
+
+	agents, next, err := a.Store.ListAgents(r.Context(), opts)
+	if errors.Is(err, store.ErrInvalidCursor) {
+		writeError(w, http.StatusBadRequest, "invalid cursor")
+		return
+	}
+	if err != nil {
+		a.logger(r).Error("list agents failed", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, "failed to list agents")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, page{Items: agents, NextCursor: next})
+}
+
+func (a *AgentsAPI) GetAgent(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	agent, err := a.Store.GetAgent(r.Context(), id)
+	if errors.Is(err, store.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "agent not found")
+		return
+	}
+	if err != nil {
+		a.logger(r).Error("get agent failed", zap.Error(err), zap.String("agent_id", id))
+		writeError(w, http.StatusInternalServerError, "failed to get agent")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, agent)
+}
+
+func (a *AgentsAPI) ListAgentTasks(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	opts := a.listOptsFromRequest(r)
+
+	tasks, next, err := a.Store.ListTasks(r.Context(), id, opts)
+	if errors.Is(err, store.ErrInvalidCursor) {
+		writeError(w, http.StatusBadRequest, "invalid cursor")
+		return
+	}
+	if err != nil {
+		a.logger(r).Error("list agent tasks failed", zap.Error(err), zap.String("agent_id", id))
+		writeError(w, http.StatusInternalServerError, "failed to list agent tasks")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, page{Items: tasks, NextCursor: next})
+}
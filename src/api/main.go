@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"os"
+	"net/http/pprof"
 	"os/signal"
 	"syscall"
 	"time"
@@ -15,89 +15,208 @@ import (
 	"github.com/redis/go-redis/v9"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/DSamuelHodge/agent360/src/authmw"
+	"github.com/DSamuelHodge/agent360/src/config"
+	"github.com/DSamuelHodge/agent360/src/httputil"
+	"github.com/DSamuelHodge/agent360/src/middleware"
+	"github.com/DSamuelHodge/agent360/src/store"
 )
 
 var (
-	logger *zap.Logger
-	rdb    *redis.Client
+	logger     *zap.Logger
+	rdb        *redis.Client
+	cfgManager *config.Manager
 )
 
 func init() {
-	// Initialize logger
+	// Initialize logger with a level that config.Manager can adjust live.
+	atomicLevel := zap.NewAtomicLevel()
+	zapCfg := zap.NewProductionConfig()
+	zapCfg.Level = atomicLevel
+
 	var err error
-	logger, err = zap.NewProduction()
+	logger, err = zapCfg.Build()
 	if err != nil {
 		log.Fatalf("Failed to initialize logger: %v", err)
 	}
-	defer logger.Sync()
 
 	// Load configuration
 	viper.SetConfigName("config")
 	viper.SetConfigType("yaml")
 	viper.AddConfigPath("./config")
 	viper.AddConfigPath(".")
+	config.SetDefaults()
 
 	if err := viper.ReadInConfig(); err != nil {
 		logger.Error("Failed to read config file", zap.Error(err))
 	}
 
+	cfgManager, err = config.NewManager(atomicLevel, logger)
+	if err != nil {
+		logger.Fatal("Failed to load configuration", zap.Error(err))
+	}
+	cfg := cfgManager.Current()
+
 	// Initialize Redis
 	rdb = redis.NewClient(&redis.Options{
-		Addr:     viper.GetString("redis.addr"),
-		Password: viper.GetString("redis.password"),
-		DB:       viper.GetInt("redis.db"),
+		Addr:     cfg.Redis.Addr,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
 	})
 }
 
 func main() {
-	r := mux.NewRouter()
-
-	// Health check endpoints
-	r.HandleFunc("/health", healthCheckHandler).Methods("GET")
-	r.HandleFunc("/ready", readinessCheckHandler).Methods("GET")
-
-	// Metrics endpoint
-	r.Handle("/metrics", promhttp.Handler())
-
-	// API routes
-	api := r.PathPrefix("/api/v1").Subrouter()
-	api.HandleFunc("/agents", listAgentsHandler).Methods("GET")
-	api.HandleFunc("/agents/{id}", getAgentHandler).Methods("GET")
-	api.HandleFunc("/agents/{id}/tasks", getAgentTasksHandler).Methods("GET")
-
-	// Create HTTP server
-	srv := &http.Server{
-		Addr:         fmt.Sprintf(":%d", viper.GetInt("server.port")),
-		Handler:      r,
-		ReadTimeout:  time.Duration(viper.GetInt("server.readTimeout")) * time.Second,
-		WriteTimeout: time.Duration(viper.GetInt("server.writeTimeout")) * time.Second,
+	cfg := cfgManager.Current()
+
+	// Public API router: only the versioned API surface is exposed here.
+	apiRouter := mux.NewRouter()
+	apiRouter.Use(middleware.RequestID(logger), middleware.AccessLog(logger), middleware.Recover(logger))
+	agentsAPI := &AgentsAPI{
+		Store:       store.NewRedisStore(rdb),
+		Log:         logger,
+		MaxPageSize: cfg.API.MaxPageSize,
 	}
 
-	// Start server in a goroutine
-	go func() {
-		logger.Info("Starting server", zap.String("addr", srv.Addr))
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			logger.Fatal("Failed to start server", zap.Error(err))
+	api := apiRouter.PathPrefix("/api/v1").Subrouter()
+	api.HandleFunc("/agents", agentsAPI.ListAgents).Methods("GET")
+	api.HandleFunc("/agents/{id}", agentsAPI.GetAgent).Methods("GET")
+	api.HandleFunc("/agents/{id}/tasks", agentsAPI.ListAgentTasks).Methods("GET")
+
+	apiSrv := httputil.NewServer(
+		fmt.Sprintf(":%d", cfg.Server.Port),
+		apiRouter,
+		httputil.Options{
+			ReadTimeout:  cfg.ReadTimeoutDuration(),
+			WriteTimeout: cfg.WriteTimeoutDuration(),
+		},
+	)
+
+	// Internal admin router: health, readiness, metrics and pprof. Bind
+	// admin.address to a private interface (or localhost) to keep these off
+	// the public network.
+	adminRouter := mux.NewRouter()
+	adminRouter.Use(middleware.RequestID(logger), middleware.AccessLog(logger), middleware.Recover(logger))
+	adminRouter.HandleFunc("/health", healthCheckHandler).Methods("GET")
+	adminRouter.HandleFunc("/ready", readinessCheckHandler).Methods("GET")
+
+	adminAuth := authmw.New(func() authmw.Config {
+		auth := cfgManager.Current().Admin.Auth
+		return authmw.Config{
+			Mode:          authmw.Mode(auth.Mode),
+			BasicUser:     auth.Basic.User,
+			BasicPassword: auth.Basic.Password,
+			BearerToken:   auth.Bearer.Token,
 		}
-	}()
+	}, logger)
+
+	protected := adminRouter.NewRoute().Subrouter()
+	protected.Use(adminAuth)
+	protected.Handle("/metrics", promhttp.Handler())
+	protected.HandleFunc("/debug/pprof/", pprof.Index)
+	protected.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	protected.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	protected.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	protected.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	adminAddr := cfg.Admin.Address
+	adminSrv := httputil.NewServer(adminAddr, adminRouter, httputil.Options{})
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	g, gCtx := errgroup.WithContext(ctx)
 
-	// Wait for interrupt signal
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	g.Go(func() error {
+		logger.Info("Starting API server", zap.String("addr", apiSrv.Addr))
+		return runUntilCanceled(gCtx, apiSrv)
+	})
+
+	if httputil.Enabled(adminAddr) {
+		g.Go(func() error {
+			logger.Info("Starting admin server", zap.String("addr", adminSrv.Addr))
+			return runUntilCanceled(gCtx, adminSrv)
+		})
+	} else {
+		logger.Info("Admin server disabled (admin.address is empty)")
+	}
+
+	g.Go(func() error {
+		return pingRedis(gCtx, rdb)
+	})
+
+	g.Go(func() error {
+		<-gCtx.Done()
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error("Component returned an error, shutting down", zap.Error(err))
+	}
 
-	// Graceful shutdown
+	// Drain public API traffic first, then tear down the admin listener.
 	logger.Info("Server is shutting down...")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	if err := srv.Shutdown(ctx); err != nil {
-		logger.Fatal("Server forced to shutdown", zap.Error(err))
+	if err := apiSrv.Shutdown(shutdownCtx); err != nil {
+		logger.Error("API server forced to shutdown", zap.Error(err))
+	}
+
+	if httputil.Enabled(adminAddr) {
+		if err := adminSrv.Shutdown(shutdownCtx); err != nil {
+			logger.Error("Admin server forced to shutdown", zap.Error(err))
+		}
 	}
 
+	if err := rdb.Close(); err != nil {
+		logger.Error("Failed to close Redis client", zap.Error(err))
+	}
+	logger.Sync()
+
 	logger.Info("Server exited properly")
 }
 
+// runUntilCanceled starts srv and blocks until either ListenAndServe fails
+// or ctx is canceled, in which case it returns nil so the errgroup doesn't
+// treat a normal shutdown as a failure.
+func runUntilCanceled(ctx context.Context, srv *http.Server) error {
+	errCh := make(chan error, 1)
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errCh <- err
+			return
+		}
+		errCh <- nil
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+// pingRedis periodically checks Redis connectivity so a broken connection
+// surfaces as a log line instead of silently failing readiness checks.
+func pingRedis(ctx context.Context, rdb *redis.Client) error {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := rdb.Ping(ctx).Err(); err != nil {
+				logger.Warn("Redis ping failed", zap.Error(err))
+			}
+		}
+	}
+}
+
 // Handler functions
 func healthCheckHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
@@ -115,23 +234,3 @@ func readinessCheckHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	fmt.Fprintf(w, "ready")
 }
-
-func listAgentsHandler(w http.ResponseWriter, r *http.Request) {
-	// TODO: Implement agent listing
-	w.WriteHeader(http.StatusOK)
-	fmt.Fprintf(w, "[]")
-}
-
-func getAgentHandler(w http.ResponseWriter, r *http.Request) {
-	// TODO: Implement single agent retrieval
-	vars := mux.Vars(r)
-	w.WriteHeader(http.StatusOK)
-	fmt.Fprintf(w, "{\"id\": \"%s\"}", vars["id"])
-}
-
-func getAgentTasksHandler(w http.ResponseWriter, r *http.Request) {
-	// TODO: Implement agent tasks retrieval
-	vars := mux.Vars(r)
-	w.WriteHeader(http.StatusOK)
-	fmt.Fprintf(w, "{\"agent_id\": \"%s\", \"tasks\": []}", vars["id"])
-}
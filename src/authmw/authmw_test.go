@@ -0,0 +1,54 @@
+package authmw
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestNew(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	tests := []struct {
+		name       string
+		cfg        Config
+		authHeader string
+		wantStatus int
+	}{
+		{"none allows anonymous", Config{Mode: ModeNone}, "", http.StatusOK},
+		{"basic with correct credentials", Config{Mode: ModeBasic, BasicUser: "u", BasicPassword: "p"}, basicHeader("u", "p"), http.StatusOK},
+		{"basic with wrong credentials", Config{Mode: ModeBasic, BasicUser: "u", BasicPassword: "p"}, basicHeader("u", "wrong"), http.StatusUnauthorized},
+		{"basic with no credentials configured, empty auth presented", Config{Mode: ModeBasic}, basicHeader("", ""), http.StatusUnauthorized},
+		{"basic with no auth header", Config{Mode: ModeBasic, BasicUser: "u", BasicPassword: "p"}, "", http.StatusUnauthorized},
+		{"bearer with correct token", Config{Mode: ModeBearer, BearerToken: "tok"}, "Bearer tok", http.StatusOK},
+		{"bearer with wrong token", Config{Mode: ModeBearer, BearerToken: "tok"}, "Bearer wrong", http.StatusUnauthorized},
+		{"bearer with no token configured, empty token presented", Config{Mode: ModeBearer}, "Bearer ", http.StatusUnauthorized},
+		{"bearer with no auth header", Config{Mode: ModeBearer, BearerToken: "tok"}, "", http.StatusUnauthorized},
+		{"unrecognized mode denies", Config{Mode: "garbage"}, "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := New(func() Config { return tt.cfg }, zap.NewNop())
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			rec := httptest.NewRecorder()
+
+			mw(http.HandlerFunc(ok)).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func basicHeader(user, pass string) string {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.SetBasicAuth(user, pass)
+	return req.Header.Get("Authorization")
+}
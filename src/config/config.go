@@ -0,0 +1,182 @@
+// Package config loads config.yaml into a typed, validated Config and
+// keeps it hot-reloadable behind an atomic snapshot so the rest of the
+// process never has to call viper.Get* at request time.
+package config
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// Server holds the public API listener settings.
+type Server struct {
+	Port         int `mapstructure:"port"`
+	ReadTimeout  int `mapstructure:"readTimeout"`
+	WriteTimeout int `mapstructure:"writeTimeout"`
+}
+
+// Basic holds HTTP Basic auth credentials for the admin listener.
+type Basic struct {
+	User     string `mapstructure:"user"`
+	Password string `mapstructure:"password"`
+}
+
+// Bearer holds the shared bearer token for the admin listener.
+type Bearer struct {
+	Token string `mapstructure:"token"`
+}
+
+// Auth configures admin.auth: none, basic, or bearer.
+type Auth struct {
+	Mode   string `mapstructure:"mode"`
+	Basic  Basic  `mapstructure:"basic"`
+	Bearer Bearer `mapstructure:"bearer"`
+}
+
+// Admin holds the internal admin listener settings.
+type Admin struct {
+	Address string `mapstructure:"address"`
+	Auth    Auth   `mapstructure:"auth"`
+}
+
+// Redis holds the Redis client settings.
+type Redis struct {
+	Addr     string `mapstructure:"addr"`
+	Password string `mapstructure:"password"`
+	DB       int    `mapstructure:"db"`
+}
+
+// API holds settings for the /api/v1 handlers.
+type API struct {
+	MaxPageSize int `mapstructure:"maxPageSize"`
+}
+
+// Log holds logging settings.
+type Log struct {
+	Level string `mapstructure:"level"`
+}
+
+// Config is the typed, validated view of config.yaml.
+type Config struct {
+	Server Server `mapstructure:"server"`
+	Admin  Admin  `mapstructure:"admin"`
+	Redis  Redis  `mapstructure:"redis"`
+	API    API    `mapstructure:"api"`
+	Log    Log    `mapstructure:"log"`
+}
+
+// ReadTimeoutDuration returns Server.ReadTimeout as a time.Duration in seconds.
+func (c Config) ReadTimeoutDuration() time.Duration {
+	return time.Duration(c.Server.ReadTimeout) * time.Second
+}
+
+// WriteTimeoutDuration returns Server.WriteTimeout as a time.Duration in seconds.
+func (c Config) WriteTimeoutDuration() time.Duration {
+	return time.Duration(c.Server.WriteTimeout) * time.Second
+}
+
+func (c Config) validate() error {
+	if c.Server.Port <= 0 {
+		return fmt.Errorf("config: server.port must be set")
+	}
+	switch c.Admin.Auth.Mode {
+	case "", "none", "basic", "bearer":
+	default:
+		return fmt.Errorf("config: admin.auth.mode %q is not one of none|basic|bearer", c.Admin.Auth.Mode)
+	}
+	if _, err := zap.ParseAtomicLevel(c.Log.Level); err != nil {
+		return fmt.Errorf("config: log.level %q: %w", c.Log.Level, err)
+	}
+	return nil
+}
+
+// SetDefaults registers sane defaults on viper so the service starts
+// without requiring an externally-supplied config.yaml. Call it before
+// viper.ReadInConfig so a partial or absent config file still validates.
+func SetDefaults() {
+	viper.SetDefault("server.port", 8080)
+	viper.SetDefault("server.readTimeout", 15)
+	viper.SetDefault("server.writeTimeout", 15)
+	viper.SetDefault("admin.address", "")
+	viper.SetDefault("admin.auth.mode", "none")
+	viper.SetDefault("redis.addr", "localhost:6379")
+	viper.SetDefault("redis.db", 0)
+	viper.SetDefault("api.maxPageSize", 100)
+	viper.SetDefault("log.level", "info")
+}
+
+var reloadsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "config_reloads_total",
+	Help: "Count of config reload attempts by outcome.",
+}, []string{"status"})
+
+// Manager owns the current validated Config snapshot and keeps it fresh as
+// config.yaml changes on disk.
+type Manager struct {
+	current atomic.Pointer[Config]
+	level   zap.AtomicLevel
+	log     *zap.Logger
+}
+
+// NewManager parses and validates the config viper has already read,
+// starts watching it for changes, and returns a Manager serving the
+// current snapshot via Current(). level is kept in sync with Config.Log.Level
+// on every successful reload.
+func NewManager(level zap.AtomicLevel, log *zap.Logger) (*Manager, error) {
+	m := &Manager{level: level, log: log}
+
+	cfg, err := parse()
+	if err != nil {
+		return nil, fmt.Errorf("config: initial load: %w", err)
+	}
+	m.store(cfg)
+
+	viper.OnConfigChange(func(_ fsnotify.Event) {
+		m.reload()
+	})
+	viper.WatchConfig()
+
+	return m, nil
+}
+
+// Current returns the most recently validated Config snapshot.
+func (m *Manager) Current() *Config {
+	return m.current.Load()
+}
+
+func (m *Manager) store(cfg *Config) {
+	m.current.Store(cfg)
+	if level, err := zap.ParseAtomicLevel(cfg.Log.Level); err == nil {
+		m.level.SetLevel(level.Level())
+	}
+}
+
+func (m *Manager) reload() {
+	cfg, err := parse()
+	if err != nil {
+		reloadsTotal.WithLabelValues("error").Inc()
+		m.log.Error("config reload rejected, keeping previous snapshot", zap.Error(err))
+		return
+	}
+	m.store(cfg)
+	reloadsTotal.WithLabelValues("ok").Inc()
+	m.log.Info("config reloaded")
+}
+
+func parse() (*Config, error) {
+	var cfg Config
+	if err := viper.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,35 @@
+// Package httputil builds generic HTTP "utility" servers that main wires
+// up for the public API listener and the internal admin listener.
+package httputil
+
+import (
+	"net/http"
+	"time"
+)
+
+// Options configures the *http.Server returned by NewServer.
+type Options struct {
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+}
+
+// NewServer builds an *http.Server bound to addr serving handler according
+// to opts. Callers should check Enabled(addr) before starting the returned
+// server; NewServer itself does no validation of addr.
+func NewServer(addr string, handler http.Handler, opts Options) *http.Server {
+	return &http.Server{
+		Addr:         addr,
+		Handler:      handler,
+		ReadTimeout:  opts.ReadTimeout,
+		WriteTimeout: opts.WriteTimeout,
+		IdleTimeout:  opts.IdleTimeout,
+	}
+}
+
+// Enabled reports whether addr configures a listener that should actually be
+// started. An empty address means "disabled" rather than requiring a
+// separate enabled flag in config.
+func Enabled(addr string) bool {
+	return addr != ""
+}
@@ -0,0 +1,108 @@
+// Package middleware holds the mux.Router middleware stack shared by the
+// API and admin routers: request IDs, panic recovery, and access logging.
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RequestIDHeader is the header used to propagate the request ID to and
+// from callers.
+const RequestIDHeader = "X-Request-ID"
+
+type contextKey int
+
+const loggerContextKey contextKey = iota
+
+// LoggerFromContext returns the request-scoped logger stashed by RequestID,
+// falling back to base if the context has none.
+func LoggerFromContext(ctx context.Context, base *zap.Logger) *zap.Logger {
+	if l, ok := ctx.Value(loggerContextKey).(*zap.Logger); ok {
+		return l
+	}
+	return base
+}
+
+// RequestID generates or propagates an X-Request-ID header and stores a
+// child logger tagged with it in the request context so downstream
+// handlers can log with correlation via LoggerFromContext.
+func RequestID(base *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(RequestIDHeader)
+			if id == "" {
+				id = uuid.NewString()
+			}
+			w.Header().Set(RequestIDHeader, id)
+
+			reqLogger := base.With(zap.String("request_id", id))
+			ctx := context.WithValue(r.Context(), loggerContextKey, reqLogger)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Recover recovers from panics in the handler chain, logs them with a stack
+// trace, and responds with 500 instead of crashing the process.
+func Recover(base *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if rec := recover(); rec != nil {
+					LoggerFromContext(r.Context(), base).Error("panic recovered",
+						zap.Any("error", rec),
+						zap.Stack("stack"),
+					)
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+			}()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// AccessLog emits a structured access log line per request.
+func AccessLog(base *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(sw, r)
+
+			LoggerFromContext(r.Context(), base).Info("request",
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+				zap.Int("status", sw.status),
+				zap.Int("bytes", sw.bytes),
+				zap.Duration("latency", time.Since(start)),
+				zap.String("request_id", sw.Header().Get(RequestIDHeader)),
+				zap.String("remote", r.RemoteAddr),
+			)
+		})
+	}
+}
+
+// statusWriter wraps http.ResponseWriter to capture the status code and
+// byte count written so AccessLog can report them.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
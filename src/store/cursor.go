@@ -0,0 +1,34 @@
+package store
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+)
+
+// encodeCursor turns an offset into an opaque, base64 page cursor.
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+// decodeCursor parses a cursor produced by encodeCursor. An empty cursor
+// decodes to offset 0 (the first page). Any error it returns wraps
+// ErrInvalidCursor so callers can tell a malformed client-supplied cursor
+// apart from a backend failure.
+func decodeCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	offset, err := strconv.Atoi(string(b))
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("%w: negative offset %d", ErrInvalidCursor, offset)
+	}
+	return offset, nil
+}
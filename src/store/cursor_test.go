@@ -0,0 +1,37 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestDecodeCursorRejectsNegativeOffset(t *testing.T) {
+	// base64(RawURLEncoding) of "-5"
+	const negativeCursor = "LTU"
+
+	_, err := decodeCursor(negativeCursor)
+	if !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("decodeCursor(%q) = %v, want ErrInvalidCursor", negativeCursor, err)
+	}
+}
+
+func TestMemoryStoreListAgentsRejectsInvalidCursor(t *testing.T) {
+	s := NewMemoryStore()
+	s.PutAgent(Agent{ID: "a1"})
+
+	_, _, err := s.ListAgents(context.Background(), ListOpts{Limit: 10, Cursor: "LTU"})
+	if !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("ListAgents with negative cursor = %v, want ErrInvalidCursor", err)
+	}
+}
+
+func TestMemoryStoreListTasksRejectsInvalidCursor(t *testing.T) {
+	s := NewMemoryStore()
+	s.PutTask(Task{ID: "t1", AgentID: "a1"})
+
+	_, _, err := s.ListTasks(context.Background(), "a1", ListOpts{Limit: 10, Cursor: "LTU"})
+	if !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("ListTasks with negative cursor = %v, want ErrInvalidCursor", err)
+	}
+}
@@ -0,0 +1,111 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// MemoryStore is an in-memory Store implementation for tests.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	agents map[string]Agent
+	order  []string
+	tasks  map[string][]Task
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		agents: make(map[string]Agent),
+		tasks:  make(map[string][]Task),
+	}
+}
+
+// PutAgent inserts or replaces an agent, for use by tests setting up fixtures.
+func (s *MemoryStore) PutAgent(agent Agent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.agents[agent.ID]; !exists {
+		s.order = append(s.order, agent.ID)
+		sort.Strings(s.order)
+	}
+	s.agents[agent.ID] = agent
+}
+
+// PutTask appends a task for an agent, for use by tests setting up fixtures.
+func (s *MemoryStore) PutTask(task Task) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tasks[task.AgentID] = append(s.tasks[task.AgentID], task)
+}
+
+func (s *MemoryStore) ListAgents(ctx context.Context, opts ListOpts) ([]Agent, string, error) {
+	offset, err := decodeCursor(opts.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	end := offset + opts.limitOrDefault()
+	if end > len(s.order) {
+		end = len(s.order)
+	}
+	if offset > len(s.order) {
+		offset = len(s.order)
+	}
+
+	ids := s.order[offset:end]
+	agents := make([]Agent, 0, len(ids))
+	for _, id := range ids {
+		agents = append(agents, s.agents[id])
+	}
+
+	var next string
+	if end < len(s.order) {
+		next = encodeCursor(end)
+	}
+	return agents, next, nil
+}
+
+func (s *MemoryStore) GetAgent(ctx context.Context, id string) (Agent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	agent, ok := s.agents[id]
+	if !ok {
+		return Agent{}, ErrNotFound
+	}
+	return agent, nil
+}
+
+func (s *MemoryStore) ListTasks(ctx context.Context, agentID string, opts ListOpts) ([]Task, string, error) {
+	offset, err := decodeCursor(opts.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	all := s.tasks[agentID]
+	end := offset + opts.limitOrDefault()
+	if end > len(all) {
+		end = len(all)
+	}
+	if offset > len(all) {
+		offset = len(all)
+	}
+
+	tasks := append([]Task(nil), all[offset:end]...)
+
+	var next string
+	if end < len(all) {
+		next = encodeCursor(end)
+	}
+	return tasks, next, nil
+}
@@ -0,0 +1,28 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestMemoryStoreListAgentsZeroLimitUsesDefault(t *testing.T) {
+	s := NewMemoryStore()
+	for i := 0; i < DefaultPageSize+5; i++ {
+		s.PutAgent(Agent{ID: fmt.Sprintf("a%02d", i)})
+	}
+
+	agents, next, err := s.ListAgents(context.Background(), ListOpts{})
+	if err != nil {
+		t.Fatalf("ListAgents: %v", err)
+	}
+	if len(agents) != DefaultPageSize {
+		t.Fatalf("len(agents) = %d, want %d", len(agents), DefaultPageSize)
+	}
+	if next == "" {
+		t.Fatal("expected a non-empty next cursor since more agents remain")
+	}
+	if next == encodeCursor(0) {
+		t.Fatal("next cursor must advance past the page just served, not repeat it")
+	}
+}
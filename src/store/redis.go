@@ -0,0 +1,98 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	agentsIndexKey = "agents:index"
+	agentKeyPrefix = "agent:"
+	tasksKeyPrefix = "agent:tasks:"
+)
+
+// RedisStore implements Store on top of an existing *redis.Client. Agents
+// are stored as hashes (one per agent, keyed by "agent:<id>") with their
+// IDs tracked in the "agents:index" sorted set for stable pagination. Tasks
+// are stored per-agent in "agent:tasks:<id>" sorted sets, scored by
+// insertion order.
+type RedisStore struct {
+	rdb *redis.Client
+}
+
+// NewRedisStore wraps rdb in a Store.
+func NewRedisStore(rdb *redis.Client) *RedisStore {
+	return &RedisStore{rdb: rdb}
+}
+
+func (s *RedisStore) ListAgents(ctx context.Context, opts ListOpts) ([]Agent, string, error) {
+	offset, err := decodeCursor(opts.Cursor)
+	if err != nil {
+		return nil, "", fmt.Errorf("store: invalid cursor: %w", err)
+	}
+
+	limit := opts.limitOrDefault()
+	ids, err := s.rdb.ZRange(ctx, agentsIndexKey, int64(offset), int64(offset+limit)).Result()
+	if err != nil {
+		return nil, "", fmt.Errorf("store: list agents: %w", err)
+	}
+
+	var next string
+	if len(ids) > limit {
+		ids = ids[:limit]
+		next = encodeCursor(offset + limit)
+	}
+
+	agents := make([]Agent, 0, len(ids))
+	for _, id := range ids {
+		agent, err := s.GetAgent(ctx, id)
+		if err != nil {
+			return nil, "", err
+		}
+		agents = append(agents, agent)
+	}
+	return agents, next, nil
+}
+
+func (s *RedisStore) GetAgent(ctx context.Context, id string) (Agent, error) {
+	vals, err := s.rdb.HGetAll(ctx, agentKeyPrefix+id).Result()
+	if err != nil {
+		return Agent{}, fmt.Errorf("store: get agent %s: %w", id, err)
+	}
+	if len(vals) == 0 {
+		return Agent{}, ErrNotFound
+	}
+	return Agent{ID: id, Name: vals["name"], Status: vals["status"]}, nil
+}
+
+func (s *RedisStore) ListTasks(ctx context.Context, agentID string, opts ListOpts) ([]Task, string, error) {
+	offset, err := decodeCursor(opts.Cursor)
+	if err != nil {
+		return nil, "", fmt.Errorf("store: invalid cursor: %w", err)
+	}
+
+	limit := opts.limitOrDefault()
+	raw, err := s.rdb.ZRange(ctx, tasksKeyPrefix+agentID, int64(offset), int64(offset+limit)).Result()
+	if err != nil {
+		return nil, "", fmt.Errorf("store: list tasks for agent %s: %w", agentID, err)
+	}
+
+	var next string
+	if len(raw) > limit {
+		raw = raw[:limit]
+		next = encodeCursor(offset + limit)
+	}
+
+	tasks := make([]Task, 0, len(raw))
+	for _, member := range raw {
+		var task Task
+		if err := json.Unmarshal([]byte(member), &task); err != nil {
+			return nil, "", fmt.Errorf("store: decode task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, next, nil
+}
@@ -0,0 +1,62 @@
+// Package store defines the persistence interface behind the agents API,
+// with a Redis-backed implementation for production and an in-memory one
+// for tests.
+package store
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned by GetAgent when no agent exists with the given ID.
+var ErrNotFound = errors.New("store: not found")
+
+// ErrInvalidCursor is returned (wrapped) by the List* methods when a
+// client-supplied cursor doesn't decode to a valid page offset.
+var ErrInvalidCursor = errors.New("store: invalid cursor")
+
+// Agent is the externally visible representation of an agent.
+type Agent struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// Task is a unit of work belonging to an agent.
+type Task struct {
+	ID      string `json:"id"`
+	AgentID string `json:"agent_id"`
+	Status  string `json:"status"`
+}
+
+// DefaultPageSize is the page size List* methods use when ListOpts.Limit is
+// not a positive number.
+const DefaultPageSize = 20
+
+// ListOpts controls pagination for the List* methods. A zero (or negative)
+// Limit means the caller wants the store's default page size.
+type ListOpts struct {
+	Limit  int
+	Cursor string
+}
+
+// limitOrDefault returns opts.Limit, or DefaultPageSize if opts.Limit isn't
+// positive.
+func (opts ListOpts) limitOrDefault() int {
+	if opts.Limit <= 0 {
+		return DefaultPageSize
+	}
+	return opts.Limit
+}
+
+// Store is the persistence interface the API handlers depend on.
+type Store interface {
+	// ListAgents returns a page of agents plus an opaque cursor for the next
+	// page, or "" if there is no more data.
+	ListAgents(ctx context.Context, opts ListOpts) ([]Agent, string, error)
+	// GetAgent returns a single agent, or ErrNotFound if it doesn't exist.
+	GetAgent(ctx context.Context, id string) (Agent, error)
+	// ListTasks returns a page of tasks belonging to agentID plus an opaque
+	// cursor for the next page, or "" if there is no more data.
+	ListTasks(ctx context.Context, agentID string, opts ListOpts) ([]Task, string, error)
+}